@@ -0,0 +1,50 @@
+package calc
+
+import "math/big"
+
+// Node is an element of the expression tree returned by Parser.Parse.
+type Node interface {
+	node()
+}
+
+// NumberLit is a literal value: a plain float64, a big.Int/big.Rat (big
+// mode), or a [Lo, Hi] enclosure (interval mode), matching the Kind it
+// was lexed under.
+type NumberLit struct {
+	Kind   Kind
+	Num    float64
+	BigInt *big.Int
+	BigRat *big.Rat
+	Lo, Hi float64
+}
+
+// Ident is a variable reference, resolved against an Env at eval time.
+type Ident struct {
+	Name string
+}
+
+// UnaryOp is a prefix operator applied to a single operand (only "-" today).
+type UnaryOp struct {
+	Op string
+	X  Node
+}
+
+// BinaryOp is an infix operator applied to two operands, including "="
+// (assignment: Left must be an *Ident, checked at eval time).
+type BinaryOp struct {
+	Op          string
+	Left, Right Node
+}
+
+// Call is a named built-in function invoked with zero or more argument
+// expressions.
+type Call struct {
+	Name string
+	Args []Node
+}
+
+func (*NumberLit) node() {}
+func (*Ident) node()     {}
+func (*UnaryOp) node()   {}
+func (*BinaryOp) node()  {}
+func (*Call) node()      {}
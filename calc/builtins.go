@@ -0,0 +1,71 @@
+package calc
+
+import (
+	"fmt"
+	"math"
+)
+
+// builtin pairs a function's implementation with the minimum argument
+// count it needs, so callBuiltin can reject a short call cleanly
+// instead of the implementation indexing off the end of a.
+type builtin struct {
+	minArgs int
+	fn      func(a []float64) float64
+}
+
+// builtinFuncs maps a function name to its implementation. Every fn
+// takes its arguments in call order as a plain float64 slice, so these
+// run at float64 precision even when the Evaluator is in big or
+// interval mode (see Evaluator.evalCall).
+var builtinFuncs = map[string]builtin{
+	"sin":   {1, func(a []float64) float64 { return math.Sin(a[0]) }},
+	"cos":   {1, func(a []float64) float64 { return math.Cos(a[0]) }},
+	"tan":   {1, func(a []float64) float64 { return math.Tan(a[0]) }},
+	"asin":  {1, func(a []float64) float64 { return math.Asin(a[0]) }},
+	"sqrt":  {1, func(a []float64) float64 { return math.Sqrt(a[0]) }},
+	"ln":    {1, func(a []float64) float64 { return math.Log(a[0]) }},
+	"exp":   {1, func(a []float64) float64 { return math.Exp(a[0]) }},
+	"abs":   {1, func(a []float64) float64 { return math.Abs(a[0]) }},
+	"floor": {1, func(a []float64) float64 { return math.Floor(a[0]) }},
+	"ceil":  {1, func(a []float64) float64 { return math.Ceil(a[0]) }},
+	"pow":   {2, func(a []float64) float64 { return math.Pow(a[0], a[1]) }},
+	"log": {1, func(a []float64) float64 {
+		if len(a) > 1 {
+			return math.Log(a[0]) / math.Log(a[1])
+		}
+		return math.Log10(a[0])
+	}},
+	"max": {1, func(a []float64) float64 {
+		m := a[0]
+		for _, v := range a[1:] {
+			if v > m {
+				m = v
+			}
+		}
+		return m
+	}},
+	"min": {1, func(a []float64) float64 {
+		m := a[0]
+		for _, v := range a[1:] {
+			if v < m {
+				m = v
+			}
+		}
+		return m
+	}},
+}
+
+// callBuiltin looks up and invokes the named built-in function, first
+// checking that enough arguments were supplied.
+func callBuiltin(name string, args []float64) (float64, error) {
+	b, ok := builtinFuncs[name]
+	if !ok {
+		return 0, fmt.Errorf("unknown function: %s", name)
+	}
+
+	if len(args) < b.minArgs {
+		return 0, fmt.Errorf("%s expects at least %d argument(s), got %d", name, b.minArgs, len(args))
+	}
+
+	return b.fn(args), nil
+}
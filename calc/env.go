@@ -0,0 +1,56 @@
+package calc
+
+import (
+	"math"
+	"math/big"
+)
+
+// Env is the symbol table threaded across Eval calls (e.g. one REPL
+// session, or successive calls from an embedding program), analogous to
+// the map the pre-refactor compute loop carried between iterations.
+type Env struct {
+	vars map[string]Value
+}
+
+// NewEnv returns an Env seeded with the predefined constants pi and e,
+// represented in whichever Kind the calculator is running as. prec is
+// the big.Float working precision used for pi/e in big mode; 0 selects
+// defaultBigPrec.
+func NewEnv(mode Kind, prec uint) *Env {
+	if prec == 0 {
+		prec = defaultBigPrec
+	}
+
+	env := &Env{vars: make(map[string]Value)}
+
+	switch mode {
+	case KindBigInt, KindBigRat:
+		piR, _ := new(big.Float).SetPrec(prec).SetFloat64(math.Pi).Rat(nil)
+		eR, _ := new(big.Float).SetPrec(prec).SetFloat64(math.E).Rat(nil)
+		env.vars["pi"] = Value{Kind: KindBigRat, BigRat: piR}
+		env.vars["e"] = Value{Kind: KindBigRat, BigRat: eR}
+
+	case KindInterval:
+		piLo, piHi := ivRound(math.Pi, math.Pi)
+		eLo, eHi := ivRound(math.E, math.E)
+		env.vars["pi"] = Value{Kind: KindInterval, Lo: piLo, Hi: piHi}
+		env.vars["e"] = Value{Kind: KindInterval, Lo: eLo, Hi: eHi}
+
+	default:
+		env.vars["pi"] = Value{Kind: KindFloat, Num: math.Pi}
+		env.vars["e"] = Value{Kind: KindFloat, Num: math.E}
+	}
+
+	return env
+}
+
+// Get looks up name, reporting whether it is defined.
+func (e *Env) Get(name string) (Value, bool) {
+	v, ok := e.vars[name]
+	return v, ok
+}
+
+// Set binds name to v, overwriting any previous binding.
+func (e *Env) Set(name string, v Value) {
+	e.vars[name] = v
+}
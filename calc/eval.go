@@ -0,0 +1,158 @@
+package calc
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Evaluator walks an expression tree produced by Parser.Parse and
+// evaluates it against an Env, in whichever Kind the calculator was
+// constructed with. Precision is the big.Float working precision, in
+// bits, used where big mode has no exact result (a rational exponent,
+// or a built-in call); zero selects defaultBigPrec.
+type Evaluator struct {
+	Mode      Kind
+	Precision uint
+}
+
+// precision returns ev.Precision, or defaultBigPrec if it wasn't set.
+func (ev Evaluator) precision() uint {
+	if ev.Precision == 0 {
+		return defaultBigPrec
+	}
+	return ev.Precision
+}
+
+// Eval evaluates n against env, resolving identifiers and applying "="
+// assignments (which bind into env) as it goes.
+func (ev Evaluator) Eval(n Node, env *Env) (Value, error) {
+	switch node := n.(type) {
+	case *NumberLit:
+		return literalValue(node), nil
+
+	case *Ident:
+		v, ok := env.Get(node.Name)
+		if !ok {
+			return Value{}, fmt.Errorf("undefined identifier: %s", node.Name)
+		}
+		return v, nil
+
+	case *UnaryOp:
+		x, err := ev.Eval(node.X, env)
+		if err != nil {
+			return Value{}, err
+		}
+		return negate(x)
+
+	case *BinaryOp:
+		return ev.evalBinary(node, env)
+
+	case *Call:
+		return ev.evalCall(node, env)
+
+	default:
+		return Value{}, fmt.Errorf("unsupported node type: %T", n)
+	}
+}
+
+func literalValue(n *NumberLit) Value {
+	switch n.Kind {
+	case KindBigInt:
+		return Value{Kind: KindBigInt, BigInt: n.BigInt}
+
+	case KindBigRat:
+		return Value{Kind: KindBigRat, BigRat: n.BigRat}
+
+	case KindInterval:
+		return Value{Kind: KindInterval, Lo: n.Lo, Hi: n.Hi}
+
+	default:
+		return Value{Kind: KindFloat, Num: n.Num}
+	}
+}
+
+func (ev Evaluator) evalBinary(node *BinaryOp, env *Env) (Value, error) {
+	if node.Op == "=" {
+		ident, ok := node.Left.(*Ident)
+		if !ok {
+			return Value{}, fmt.Errorf("left-hand side of '=' must be an identifier")
+		}
+
+		rhs, err := ev.Eval(node.Right, env)
+		if err != nil {
+			return Value{}, err
+		}
+
+		env.Set(ident.Name, rhs)
+		return rhs, nil
+	}
+
+	lhs, err := ev.Eval(node.Left, env)
+	if err != nil {
+		return Value{}, err
+	}
+
+	rhs, err := ev.Eval(node.Right, env)
+	if err != nil {
+		return Value{}, err
+	}
+
+	var result Value
+	switch lhs.Kind {
+	case KindBigInt, KindBigRat:
+		result, err = bigBinOp(lhs, rhs, node.Op, ev.precision())
+
+	case KindInterval:
+		result, err = intervalBinOp(lhs, rhs, node.Op)
+
+	default:
+		result, err = floatBinOp(lhs, rhs, node.Op)
+	}
+	if err != nil {
+		return Value{}, err
+	}
+
+	// Preserve a non-fatal warning from either operand (e.g. an
+	// interval division by zero several levels down the tree) so it
+	// still reaches the caller of the outermost Eval.
+	if result.Warning == "" {
+		if lhs.Warning != "" {
+			result.Warning = lhs.Warning
+		} else {
+			result.Warning = rhs.Warning
+		}
+	}
+
+	return result, nil
+}
+
+func (ev Evaluator) evalCall(node *Call, env *Env) (Value, error) {
+	args := make([]float64, len(node.Args))
+	for i, a := range node.Args {
+		v, err := ev.Eval(a, env)
+		if err != nil {
+			return Value{}, err
+		}
+		args[i] = v.toFloat()
+	}
+
+	switch ev.Mode {
+	case KindInterval:
+		return Value{}, fmt.Errorf("built-in functions are not supported in interval mode: %s", node.Name)
+
+	case KindBigInt, KindBigRat:
+		f, err := callBuiltin(node.Name, args)
+		if err != nil {
+			return Value{}, err
+		}
+		r, _ := new(big.Float).SetPrec(ev.precision()).SetFloat64(f).Rat(nil)
+		return Value{Kind: KindBigRat, BigRat: r}, nil
+
+	default:
+		f, err := callBuiltin(node.Name, args)
+		if err != nil {
+			return Value{}, err
+		}
+		return Value{Kind: KindFloat, Num: f}, nil
+	}
+}
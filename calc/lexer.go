@@ -0,0 +1,287 @@
+package calc
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// StringLexer is the default Lexer implementation: it scans src one
+// Token at a time rather than lexing the whole expression up front, so
+// a Parser can drive it incrementally and other sources (or test
+// doubles) can implement the same Lexer interface.
+type StringLexer struct {
+	src  string
+	pos  int
+	mode Kind
+
+	// prevOp is true when the previous token makes a following '-'
+	// unary: at the start of input, right after another operator, or
+	// right after '('.
+	prevOp bool
+}
+
+// NewLexer returns a StringLexer over src that produces numeric
+// literals in the given Kind (KindFloat, KindBigInt/KindBigRat, or
+// KindInterval).
+func NewLexer(src string, mode Kind) *StringLexer {
+	return &StringLexer{src: src, mode: mode, prevOp: true}
+}
+
+// Lex returns the next Token, or a TokEOF Token once src is exhausted.
+func (l *StringLexer) Lex() (Token, error) {
+	for l.pos < len(l.src) && (l.src[l.pos] == ' ' || l.src[l.pos] == '\t' || l.src[l.pos] == '\r') {
+		l.pos++
+	}
+
+	if l.pos >= len(l.src) {
+		return Token{Type: TokEOF}, nil
+	}
+
+	c := l.src[l.pos]
+
+	switch {
+	case c == '(':
+		l.pos++
+		l.prevOp = true
+		return Token{Type: TokLParen, Text: "(", Precedence: 9}, nil
+
+	case c == ')':
+		l.pos++
+		l.prevOp = false
+		return Token{Type: TokRParen, Text: ")"}, nil
+
+	case c == ',':
+		l.pos++
+		l.prevOp = true
+		return Token{Type: TokComma, Text: ","}, nil
+
+	case c == '[':
+		if l.mode != KindInterval {
+			return Token{}, fmt.Errorf("unexpected: '[' (enable interval literals with KindInterval mode)")
+		}
+		return l.lexIntervalBracket()
+
+	case c == '-':
+		l.pos++
+		unary := l.prevOp
+		l.prevOp = true
+		if unary {
+			return Token{Type: TokOp, Text: "-", Unary: true, Precedence: 5, RAssociative: true}, nil
+		}
+		return Token{Type: TokOp, Text: "-", Precedence: 2}, nil
+
+	case c == '+':
+		l.pos++
+		l.prevOp = true
+		return Token{Type: TokOp, Text: "+", Precedence: 2}, nil
+
+	case c == '/':
+		l.pos++
+		l.prevOp = true
+		return Token{Type: TokOp, Text: "/", Precedence: 3}, nil
+
+	case c == '*':
+		l.pos++
+		l.prevOp = true
+		return Token{Type: TokOp, Text: "*", Precedence: 3}, nil
+
+	// 'x'/'X' only read as a multiply alias (e.g. "3x4") when an operand
+	// is expected to precede it *and* a digit immediately follows, i.e.
+	// the classic "NxN" idiom with no space around the 'x'. Otherwise
+	// it's the start of an identifier ("x", "xyz", ...) or a reference
+	// to one ("2x" meaning "2 * x", "2 x" the same with a space) -
+	// lexIdent below handles both.
+	case (c == 'x' || c == 'X') && !l.prevOp && l.pos+1 < len(l.src) && unicode.IsDigit(rune(l.src[l.pos+1])):
+		l.pos++
+		l.prevOp = true
+		return Token{Type: TokOp, Text: "*", Precedence: 3}, nil
+
+	case c == '%':
+		l.pos++
+		l.prevOp = true
+		return Token{Type: TokOp, Text: "%", Precedence: 6}, nil
+
+	case c == '^':
+		l.pos++
+		l.prevOp = true
+		return Token{Type: TokOp, Text: "^", Precedence: 4, RAssociative: true}, nil
+
+	case c == '=':
+		l.pos++
+		l.prevOp = true
+		return Token{Type: TokOp, Text: "=", Precedence: 1, RAssociative: true}, nil
+
+	case l.mode == KindInterval && (unicode.IsDigit(rune(c)) || c == '.'):
+		return l.lexIntervalNumber()
+
+	case unicode.IsDigit(rune(c)) || c == '.':
+		return l.lexNumber()
+
+	case unicode.IsLetter(rune(c)) || c == '_':
+		return l.lexIdent()
+
+	default:
+		return Token{}, fmt.Errorf("unexpected character: %q", string(c))
+	}
+}
+
+// lexNumber scans a plain (non-interval) numeric literal, producing a
+// KindFloat token unless the StringLexer is in big mode, in which case
+// it produces a KindBigInt or KindBigRat token depending on whether a
+// '.' was seen.
+func (l *StringLexer) lexNumber() (Token, error) {
+	start := l.pos
+	seenDot := false
+
+	if l.src[l.pos] == '.' {
+		if l.pos+1 >= len(l.src) || !unicode.IsDigit(rune(l.src[l.pos+1])) {
+			return Token{}, fmt.Errorf("unexpected: '.'")
+		}
+		seenDot = true
+	}
+
+	l.pos++
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case unicode.IsDigit(rune(c)) || c == '_':
+			l.pos++
+
+		case c == '.':
+			if seenDot {
+				return Token{}, fmt.Errorf("redefinition of float: %q", l.src[start:l.pos+1])
+			}
+			seenDot = true
+			l.pos++
+
+		default:
+			return l.finishNumber(start, seenDot)
+		}
+	}
+
+	return l.finishNumber(start, seenDot)
+}
+
+func (l *StringLexer) finishNumber(start int, seenDot bool) (Token, error) {
+	text := strings.ReplaceAll(l.src[start:l.pos], "_", "")
+	l.prevOp = false
+
+	if l.mode != KindBigInt && l.mode != KindBigRat {
+		f, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return Token{}, fmt.Errorf("invalid number literal: %q", text)
+		}
+		return Token{Type: TokNumber, Text: text, Kind: KindFloat, Num: f}, nil
+	}
+
+	if seenDot {
+		r, ok := new(big.Rat).SetString(text)
+		if !ok {
+			return Token{}, fmt.Errorf("invalid rational literal: %q", text)
+		}
+		return Token{Type: TokNumber, Text: text, Kind: KindBigRat, BigRat: r}, nil
+	}
+
+	n, ok := new(big.Int).SetString(text, 10)
+	if !ok {
+		return Token{}, fmt.Errorf("invalid integer literal: %q", text)
+	}
+	return Token{Type: TokNumber, Text: text, Kind: KindBigInt, BigInt: n}, nil
+}
+
+// lexIntervalBracket scans a "[lo, hi]" literal.
+func (l *StringLexer) lexIntervalBracket() (Token, error) {
+	start := l.pos
+	j := l.pos + 1
+	loStart := j
+	for j < len(l.src) && l.src[j] != ',' {
+		j++
+	}
+	if j >= len(l.src) {
+		return Token{}, fmt.Errorf("unterminated interval literal: %q", l.src[start:])
+	}
+	lo, err := strconv.ParseFloat(strings.TrimSpace(l.src[loStart:j]), 64)
+	if err != nil {
+		return Token{}, fmt.Errorf("invalid interval literal: %w", err)
+	}
+
+	j++ // skip ','
+	hiStart := j
+	for j < len(l.src) && l.src[j] != ']' {
+		j++
+	}
+	if j >= len(l.src) {
+		return Token{}, fmt.Errorf("unterminated interval literal: %q", l.src[start:])
+	}
+	hi, err := strconv.ParseFloat(strings.TrimSpace(l.src[hiStart:j]), 64)
+	if err != nil {
+		return Token{}, fmt.Errorf("invalid interval literal: %w", err)
+	}
+
+	if lo > hi {
+		return Token{}, fmt.Errorf("invalid interval literal: lo (%v) > hi (%v)", lo, hi)
+	}
+
+	l.pos = j + 1
+	l.prevOp = false
+	return Token{Type: TokInterval, Text: l.src[start:l.pos], Lo: lo, Hi: hi}, nil
+}
+
+// lexIntervalNumber scans a plain number or a "center±margin" literal in
+// interval mode. '±' is the two-byte UTF-8 sequence 0xC2 0xB1. Unlike
+// lexIntervalBracket, there's no lo > hi case to reject here: margin
+// only ever widens the enclosure outward from center.
+func (l *StringLexer) lexIntervalNumber() (Token, error) {
+	start := l.pos
+	center, j, err := scanFloat(l.src, l.pos)
+	if err != nil {
+		return Token{}, err
+	}
+	lo, hi := center, center
+
+	if j+1 < len(l.src) && l.src[j] == 0xC2 && l.src[j+1] == 0xB1 {
+		margin, j2, err := scanFloat(l.src, j+2)
+		if err != nil {
+			return Token{}, err
+		}
+		lo = math.Nextafter(center-margin, math.Inf(-1))
+		hi = math.Nextafter(center+margin, math.Inf(1))
+		j = j2
+	}
+
+	l.pos = j
+	l.prevOp = false
+	return Token{Type: TokInterval, Text: l.src[start:j], Lo: lo, Hi: hi}, nil
+}
+
+// scanFloat reads a run of digits/'.' starting at i and parses it as a
+// float64, returning the value and the index just past the last digit.
+func scanFloat(data string, i int) (float64, int, error) {
+	start := i
+	for i < len(data) && (unicode.IsDigit(rune(data[i])) || data[i] == '.') {
+		i++
+	}
+
+	f, err := strconv.ParseFloat(data[start:i], 64)
+	if err != nil {
+		return 0, i, fmt.Errorf("invalid number literal: %q", data[start:i])
+	}
+
+	return f, i, nil
+}
+
+// lexIdent scans an identifier or, if immediately followed by '(', a
+// function-call name.
+func (l *StringLexer) lexIdent() (Token, error) {
+	start := l.pos
+	for l.pos < len(l.src) && (unicode.IsLetter(rune(l.src[l.pos])) || unicode.IsDigit(rune(l.src[l.pos])) || l.src[l.pos] == '_') {
+		l.pos++
+	}
+
+	l.prevOp = false
+	return Token{Type: TokIdent, Text: l.src[start:l.pos]}, nil
+}
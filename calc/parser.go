@@ -0,0 +1,172 @@
+package calc
+
+import "fmt"
+
+// Parser turns a stream of Tokens from any Lexer implementation into an
+// expression tree via precedence climbing, so Evaluator.Eval can walk
+// it directly instead of working off an intermediate RPN queue.
+type Parser struct {
+	lex Lexer
+	cur Token
+}
+
+// Parse consumes lex until it is exhausted and returns the root of the
+// expression tree, or the first error the Lexer or Parser encountered.
+func (p *Parser) Parse(lex Lexer) (Node, error) {
+	p.lex = lex
+
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	n, err := p.parseExpr(0)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.cur.Type != TokEOF {
+		return nil, fmt.Errorf("unexpected token: %q", p.cur.Text)
+	}
+
+	return n, nil
+}
+
+func (p *Parser) advance() error {
+	t, err := p.lex.Lex()
+	if err != nil {
+		return err
+	}
+
+	p.cur = t
+	return nil
+}
+
+// parseExpr implements precedence climbing: it parses a unary operand,
+// then repeatedly folds in any following binary operator whose
+// precedence is at least minPrec.
+func (p *Parser) parseExpr(minPrec int) (Node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.cur.Type == TokOp && p.cur.Precedence >= minPrec {
+		opTok := p.cur
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		next := opTok.Precedence + 1
+		if opTok.RAssociative {
+			next = opTok.Precedence
+		}
+
+		right, err := p.parseExpr(next)
+		if err != nil {
+			return nil, err
+		}
+
+		left = &BinaryOp{Op: opTok.Text, Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+func (p *Parser) parseUnary() (Node, error) {
+	if p.cur.Type == TokOp && p.cur.Unary {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		return &UnaryOp{Op: "-", X: x}, nil
+	}
+
+	return p.parsePrimary()
+}
+
+func (p *Parser) parsePrimary() (Node, error) {
+	switch p.cur.Type {
+	case TokNumber:
+		lit := &NumberLit{Kind: p.cur.Kind, Num: p.cur.Num, BigInt: p.cur.BigInt, BigRat: p.cur.BigRat}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		return lit, nil
+
+	case TokInterval:
+		lit := &NumberLit{Kind: KindInterval, Lo: p.cur.Lo, Hi: p.cur.Hi}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		return lit, nil
+
+	case TokIdent:
+		name := p.cur.Text
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if p.cur.Type == TokLParen {
+			return p.parseCall(name)
+		}
+		return &Ident{Name: name}, nil
+
+	case TokLParen:
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		n, err := p.parseExpr(0)
+		if err != nil {
+			return nil, err
+		}
+		if p.cur.Type != TokRParen {
+			return nil, fmt.Errorf("expected ')', got %q", p.cur.Text)
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		return n, nil
+
+	default:
+		return nil, fmt.Errorf("unexpected token: %q", p.cur.Text)
+	}
+}
+
+// parseCall parses the argument list of a call to the already-consumed
+// identifier name; p.cur is the '(' that follows it.
+func (p *Parser) parseCall(name string) (Node, error) {
+	if err := p.advance(); err != nil { // consume '('
+		return nil, err
+	}
+
+	var args []Node
+	if p.cur.Type != TokRParen {
+		for {
+			arg, err := p.parseExpr(1) // above '=' precedence: a call argument is never an assignment
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+
+			if p.cur.Type != TokComma {
+				break
+			}
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if p.cur.Type != TokRParen {
+		return nil, fmt.Errorf("expected ')', got %q", p.cur.Text)
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	return &Call{Name: name, Args: args}, nil
+}
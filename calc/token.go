@@ -0,0 +1,41 @@
+package calc
+
+import "math/big"
+
+// TokenType identifies the lexical category of a Token.
+type TokenType int
+
+const (
+	TokEOF TokenType = iota
+	TokNumber
+	TokInterval
+	TokIdent
+	TokOp
+	TokLParen
+	TokRParen
+	TokComma
+)
+
+// Token is a single lexical unit produced by a Lexer. Which of Num,
+// BigInt, BigRat, or Lo/Hi is populated depends on Type and, for
+// TokNumber, on Kind.
+type Token struct {
+	Type         TokenType
+	Text         string
+	Kind         Kind
+	Num          float64
+	BigInt       *big.Int
+	BigRat       *big.Rat
+	Lo, Hi       float64
+	Unary        bool
+	RAssociative bool
+	Precedence   int
+}
+
+// Lexer produces one Token at a time. Lex returns a TokEOF token once
+// the input is exhausted, and a non-nil error on the first malformed
+// input it encounters; a Parser stops consuming as soon as either is
+// reported.
+type Lexer interface {
+	Lex() (Token, error)
+}
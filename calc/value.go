@@ -0,0 +1,375 @@
+package calc
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// Kind identifies which numeric representation a Token, NumberLit, or
+// Value holds.
+type Kind int
+
+const (
+	KindFloat Kind = iota
+	KindBigInt
+	KindBigRat
+	KindInterval
+)
+
+// defaultBigPrec is the working precision, in bits, used when a rational
+// exponent forces a fallback to big.Float (there is no exact closed form
+// for e.g. (1/3)^(1/2) in big.Rat) and the caller didn't ask for a
+// specific precision. Callers that care (Evaluator, NewEnv) can override
+// it per instance.
+const defaultBigPrec uint = 256
+
+// Value is the result of evaluating a Node: a plain float64, an exact
+// big.Int/big.Rat, or a [Lo, Hi] interval enclosure, depending on Kind.
+// Warning carries a non-fatal note (e.g. an interval division whose
+// divisor straddles zero) alongside an otherwise-valid result.
+type Value struct {
+	Kind    Kind
+	Num     float64
+	BigInt  *big.Int
+	BigRat  *big.Rat
+	Lo, Hi  float64
+	Warning string
+}
+
+// String renders v the same way the calculator prints a result.
+func (v Value) String() string {
+	switch v.Kind {
+	case KindBigInt:
+		return v.BigInt.String()
+
+	case KindBigRat:
+		return v.BigRat.RatString()
+
+	case KindInterval:
+		return fmt.Sprintf("[%s, %s] (width %s)",
+			strconv.FormatFloat(v.Lo, 'g', -1, 64),
+			strconv.FormatFloat(v.Hi, 'g', -1, 64),
+			strconv.FormatFloat(v.Hi-v.Lo, 'g', -1, 64))
+
+	default:
+		return strconv.FormatFloat(v.Num, 'g', -1, 64)
+	}
+}
+
+// toFloat widens v down to a float64 so it can feed builtinFuncs, which
+// only know float64.
+func (v Value) toFloat() float64 {
+	switch v.Kind {
+	case KindBigInt:
+		f, _ := new(big.Float).SetInt(v.BigInt).Float64()
+		return f
+
+	case KindBigRat:
+		f, _ := new(big.Float).SetRat(v.BigRat).Float64()
+		return f
+
+	case KindInterval:
+		return (v.Lo + v.Hi) / 2
+
+	default:
+		return v.Num
+	}
+}
+
+func negate(v Value) (Value, error) {
+	switch v.Kind {
+	case KindBigInt:
+		return Value{Kind: KindBigInt, BigInt: new(big.Int).Neg(v.BigInt)}, nil
+
+	case KindBigRat:
+		return Value{Kind: KindBigRat, BigRat: new(big.Rat).Neg(v.BigRat)}, nil
+
+	case KindInterval:
+		return ivNeg(v), nil
+
+	default:
+		return Value{Kind: KindFloat, Num: -v.Num}, nil
+	}
+}
+
+func floatBinOp(lhs, rhs Value, op string) (Value, error) {
+	var result float64
+
+	switch op {
+	case "^":
+		result = math.Pow(lhs.Num, rhs.Num)
+
+	case "*":
+		result = lhs.Num * rhs.Num
+
+	case "/":
+		result = lhs.Num / rhs.Num
+
+	case "+":
+		result = lhs.Num + rhs.Num
+
+	case "-":
+		result = lhs.Num - rhs.Num
+
+	case "%":
+		result = math.Mod(lhs.Num, rhs.Num)
+
+	default:
+		return Value{}, fmt.Errorf("unsupported operator: %s", op)
+	}
+
+	return Value{Kind: KindFloat, Num: result}, nil
+}
+
+// toRat widens a KindBigInt/KindBigRat value to a *big.Rat so mixed-kind
+// arithmetic has a common representation to operate on.
+func toRat(v Value) *big.Rat {
+	if v.Kind == KindBigRat {
+		return v.BigRat
+	}
+
+	return new(big.Rat).SetInt(v.BigInt)
+}
+
+// bigBinOp picks the widest of lhs/rhs (big.Int unless either side is
+// already a big.Rat) and performs op on that kind. prec is the working
+// precision, in bits, used only by the big.Float fallback for a
+// rational exponent (the "^" case below); every other case is exact.
+func bigBinOp(lhs, rhs Value, op string, prec uint) (Value, error) {
+	if lhs.Kind == KindBigInt && rhs.Kind == KindBigInt {
+		switch op {
+		case "+":
+			return Value{Kind: KindBigInt, BigInt: new(big.Int).Add(lhs.BigInt, rhs.BigInt)}, nil
+
+		case "-":
+			return Value{Kind: KindBigInt, BigInt: new(big.Int).Sub(lhs.BigInt, rhs.BigInt)}, nil
+
+		case "*":
+			return Value{Kind: KindBigInt, BigInt: new(big.Int).Mul(lhs.BigInt, rhs.BigInt)}, nil
+
+		case "%":
+			if rhs.BigInt.Sign() == 0 {
+				return Value{}, fmt.Errorf("division by zero")
+			}
+			return Value{Kind: KindBigInt, BigInt: new(big.Int).Mod(lhs.BigInt, rhs.BigInt)}, nil
+
+		case "^":
+			if rhs.BigInt.Sign() < 0 {
+				if lhs.BigInt.Sign() == 0 {
+					return Value{}, fmt.Errorf("zero cannot be raised to a negative power")
+				}
+				pos := new(big.Int).Neg(rhs.BigInt)
+				r := new(big.Rat).SetFrac(big.NewInt(1), new(big.Int).Exp(lhs.BigInt, pos, nil))
+				return Value{Kind: KindBigRat, BigRat: r}, nil
+			}
+			return Value{Kind: KindBigInt, BigInt: new(big.Int).Exp(lhs.BigInt, rhs.BigInt, nil)}, nil
+
+		case "/":
+			if rhs.BigInt.Sign() == 0 {
+				return Value{}, fmt.Errorf("division by zero")
+			}
+			r := new(big.Rat).SetFrac(lhs.BigInt, rhs.BigInt)
+			if r.IsInt() {
+				return Value{Kind: KindBigInt, BigInt: r.Num()}, nil
+			}
+			return Value{Kind: KindBigRat, BigRat: r}, nil
+		}
+	}
+
+	lr, rr := toRat(lhs), toRat(rhs)
+
+	switch op {
+	case "+":
+		return Value{Kind: KindBigRat, BigRat: new(big.Rat).Add(lr, rr)}, nil
+
+	case "-":
+		return Value{Kind: KindBigRat, BigRat: new(big.Rat).Sub(lr, rr)}, nil
+
+	case "*":
+		return Value{Kind: KindBigRat, BigRat: new(big.Rat).Mul(lr, rr)}, nil
+
+	case "/":
+		if rr.Sign() == 0 {
+			return Value{}, fmt.Errorf("division by zero")
+		}
+		return Value{Kind: KindBigRat, BigRat: new(big.Rat).Quo(lr, rr)}, nil
+
+	case "^":
+		// No exact big.Rat power for a rational exponent; fall back to
+		// computing lhs^(num(rr)/denom(rr)) as an integer power (exact,
+		// via big.Rat) followed by a Newton's-method root at prec bits,
+		// so the result actually carries prec bits of precision instead
+		// of being capped at float64 the moment a fraction is involved.
+		rr2, err := bigPowRat(lr, rr.Num(), rr.Denom(), prec)
+		if err != nil {
+			return Value{}, err
+		}
+		return Value{Kind: KindBigRat, BigRat: rr2}, nil
+
+	case "%":
+		return Value{}, fmt.Errorf("'%%' is not defined for rational operands")
+	}
+
+	return Value{}, fmt.Errorf("unsupported operator: %s", op)
+}
+
+// ratPow raises base to the non-negative integer power n, exactly, via
+// big.Int.Exp on the numerator and denominator.
+func ratPow(base *big.Rat, n *big.Int) *big.Rat {
+	num := new(big.Int).Exp(base.Num(), n, nil)
+	den := new(big.Int).Exp(base.Denom(), n, nil)
+	return new(big.Rat).SetFrac(num, den)
+}
+
+// bigPowRat raises base to the rational power num/den (den > 0, per
+// big.Rat's normal form), returning the den-th root of base^num. The
+// root is found with Newton's method carried out in big.Float at prec
+// bits throughout, rather than by rounding down to float64 and calling
+// math.Pow: the float64 result only seeds the initial guess, and every
+// refinement step runs at the requested precision.
+func bigPowRat(base *big.Rat, num, den *big.Int, prec uint) (*big.Rat, error) {
+	absNum := new(big.Int).Abs(num)
+	if !absNum.IsInt64() || !den.IsInt64() {
+		return nil, fmt.Errorf("exponent too large for a big.Float fallback")
+	}
+
+	powered := ratPow(base, absNum)
+	if num.Sign() < 0 {
+		if powered.Sign() == 0 {
+			return nil, fmt.Errorf("zero cannot be raised to a negative power")
+		}
+		powered = new(big.Rat).Inv(powered)
+	}
+
+	n := den.Int64()
+	if n == 1 {
+		return powered, nil
+	}
+	if powered.Sign() < 0 {
+		return nil, fmt.Errorf("fractional power of a negative number is not supported")
+	}
+
+	root := bigNthRoot(powered, n, prec)
+	result, _ := root.Rat(nil)
+	return result, nil
+}
+
+// bigNthRoot computes the n-th root (n >= 2) of the non-negative rational
+// v to prec bits via Newton's method: x_{k+1} = ((n-1)*x_k + v/x_k^(n-1)) / n.
+// The float64 quotient only bootstraps the initial guess; convergence is
+// carried out entirely in big.Float at prec, doubling the number of
+// correct bits roughly every iteration.
+func bigNthRoot(v *big.Rat, n int64, prec uint) *big.Float {
+	vf := new(big.Float).SetPrec(prec).SetRat(v)
+	nf := new(big.Float).SetPrec(prec).SetInt64(n)
+	nMinusOne := new(big.Float).SetPrec(prec).SetInt64(n - 1)
+
+	seed, _ := vf.Float64()
+	x := new(big.Float).SetPrec(prec).SetFloat64(math.Pow(seed, 1/float64(n)))
+
+	iterations := 1
+	for bits := uint(53); bits < prec; bits *= 2 {
+		iterations++
+	}
+	iterations += 4
+
+	for i := 0; i < iterations; i++ {
+		xPow := new(big.Float).SetPrec(prec).Copy(x)
+		for k := int64(1); k < n-1; k++ {
+			xPow.Mul(xPow, x)
+		}
+
+		term := new(big.Float).SetPrec(prec).Quo(vf, xPow)
+		next := new(big.Float).SetPrec(prec).Mul(nMinusOne, x)
+		next.Add(next, term)
+		x = next.Quo(next, nf)
+	}
+
+	return x
+}
+
+// ivRound widens [lo, hi] outward by one ULP in each direction so the
+// result stays a sound (conservative) enclosure of the true interval
+// despite float64 rounding of the arithmetic that produced lo and hi.
+func ivRound(lo, hi float64) (float64, float64) {
+	return math.Nextafter(lo, math.Inf(-1)), math.Nextafter(hi, math.Inf(1))
+}
+
+func ivAdd(a, b Value) Value {
+	lo, hi := ivRound(a.Lo+b.Lo, a.Hi+b.Hi)
+	return Value{Kind: KindInterval, Lo: lo, Hi: hi}
+}
+
+func ivSub(a, b Value) Value {
+	lo, hi := ivRound(a.Lo-b.Hi, a.Hi-b.Lo)
+	return Value{Kind: KindInterval, Lo: lo, Hi: hi}
+}
+
+func ivMul(a, b Value) Value {
+	p1, p2, p3, p4 := a.Lo*b.Lo, a.Lo*b.Hi, a.Hi*b.Lo, a.Hi*b.Hi
+	lo := math.Min(math.Min(p1, p2), math.Min(p3, p4))
+	hi := math.Max(math.Max(p1, p2), math.Max(p3, p4))
+	lo, hi = ivRound(lo, hi)
+	return Value{Kind: KindInterval, Lo: lo, Hi: hi}
+}
+
+// ivDiv divides a by b. If b straddles zero the true quotient is
+// unbounded, so it returns the (-Inf, +Inf) interval with Warning set;
+// the caller decides whether/how to surface that to a user.
+func ivDiv(a, b Value) Value {
+	if b.Lo <= 0 && b.Hi >= 0 {
+		return Value{
+			Kind:    KindInterval,
+			Lo:      math.Inf(-1),
+			Hi:      math.Inf(1),
+			Warning: "division by an interval containing zero: result is unbounded",
+		}
+	}
+
+	rLo, rHi := ivRound(1/b.Hi, 1/b.Lo)
+	return ivMul(a, Value{Kind: KindInterval, Lo: rLo, Hi: rHi})
+}
+
+func ivNeg(a Value) Value {
+	return Value{Kind: KindInterval, Lo: -a.Hi, Hi: -a.Lo}
+}
+
+// ivPow raises a to the non-negative integer power n by repeated
+// multiplication. A fractional or negative exponent has no sound,
+// single-valued interval enclosure here, so intervalBinOp rejects it
+// before calling ivPow.
+func ivPow(a Value, n int) Value {
+	result := Value{Kind: KindInterval, Lo: 1, Hi: 1}
+	for k := 0; k < n; k++ {
+		result = ivMul(result, a)
+	}
+
+	return result
+}
+
+func intervalBinOp(lhs, rhs Value, op string) (Value, error) {
+	switch op {
+	case "+":
+		return ivAdd(lhs, rhs), nil
+
+	case "-":
+		return ivSub(lhs, rhs), nil
+
+	case "*":
+		return ivMul(lhs, rhs), nil
+
+	case "/":
+		return ivDiv(lhs, rhs), nil
+
+	case "^":
+		if rhs.Lo != rhs.Hi || rhs.Lo != math.Trunc(rhs.Lo) || rhs.Lo < 0 {
+			return Value{}, fmt.Errorf("interval exponent must be a non-negative integer")
+		}
+		return ivPow(lhs, int(rhs.Lo)), nil
+
+	default:
+		return Value{}, fmt.Errorf("operator not supported in interval mode: %s", op)
+	}
+}
@@ -0,0 +1,98 @@
+package calc
+
+import (
+	"math/big"
+	"testing"
+)
+
+func bigIntVal(n int64) Value {
+	return Value{Kind: KindBigInt, BigInt: big.NewInt(n)}
+}
+
+func TestBigBinOpErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		lhs, rhs Value
+		op       string
+	}{
+		{"division by zero", bigIntVal(1), bigIntVal(0), "/"},
+		{"modulo by zero", bigIntVal(5), bigIntVal(0), "%"},
+		{"zero raised to a negative power", bigIntVal(0), bigIntVal(-1), "^"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := bigBinOp(tt.lhs, tt.rhs, tt.op, defaultBigPrec); err == nil {
+				t.Fatalf("bigBinOp(%v, %v, %q) = nil error, want one", tt.lhs, tt.rhs, tt.op)
+			}
+		})
+	}
+}
+
+func TestBigBinOpExact(t *testing.T) {
+	result, err := bigBinOp(bigIntVal(6), bigIntVal(2), "/", defaultBigPrec)
+	if err != nil {
+		t.Fatalf("bigBinOp(6, 2, \"/\") returned error: %v", err)
+	}
+	if result.Kind != KindBigInt || result.BigInt.Cmp(big.NewInt(3)) != 0 {
+		t.Fatalf("bigBinOp(6, 2, \"/\") = %v, want exact big.Int 3", result)
+	}
+}
+
+func TestBigPowRatRejectsNegativeBase(t *testing.T) {
+	base := big.NewRat(-8, 1)
+	_, err := bigPowRat(base, big.NewInt(1), big.NewInt(3), defaultBigPrec)
+	if err == nil {
+		t.Fatalf("bigPowRat(-8, 1/3) = nil error, want one (fractional power of a negative number)")
+	}
+}
+
+func TestBigPowRatRejectsZeroToNegativePower(t *testing.T) {
+	base := big.NewRat(0, 1)
+	_, err := bigPowRat(base, big.NewInt(-1), big.NewInt(1), defaultBigPrec)
+	if err == nil {
+		t.Fatalf("bigPowRat(0, -1) = nil error, want one (zero to a negative power)")
+	}
+}
+
+func TestBigPowRatExactIntegerRoot(t *testing.T) {
+	base := big.NewRat(8, 1)
+	result, err := bigPowRat(base, big.NewInt(1), big.NewInt(3), defaultBigPrec)
+	if err != nil {
+		t.Fatalf("bigPowRat(8, 1/3) returned error: %v", err)
+	}
+	if result.Cmp(big.NewRat(2, 1)) != 0 {
+		t.Fatalf("bigPowRat(8, 1/3) = %v, want 2", result)
+	}
+}
+
+func TestIntervalBinOpZeroStraddlingDivisor(t *testing.T) {
+	lhs := Value{Kind: KindInterval, Lo: 1, Hi: 2}
+	rhs := Value{Kind: KindInterval, Lo: -1, Hi: 1}
+
+	result, err := intervalBinOp(lhs, rhs, "/")
+	if err != nil {
+		t.Fatalf("intervalBinOp(/) with a zero-straddling divisor returned error: %v", err)
+	}
+	if result.Warning == "" {
+		t.Fatalf("intervalBinOp(/) with a zero-straddling divisor = %v, want a Warning set", result)
+	}
+}
+
+func TestIntervalBinOpNonIntegerExponent(t *testing.T) {
+	lhs := Value{Kind: KindInterval, Lo: 1, Hi: 2}
+	rhs := Value{Kind: KindInterval, Lo: 0.5, Hi: 0.5}
+
+	if _, err := intervalBinOp(lhs, rhs, "^"); err == nil {
+		t.Fatalf("intervalBinOp(^) with a non-integer exponent = nil error, want one")
+	}
+}
+
+func TestIntervalBinOpNegativeExponent(t *testing.T) {
+	lhs := Value{Kind: KindInterval, Lo: 1, Hi: 2}
+	rhs := Value{Kind: KindInterval, Lo: -1, Hi: -1}
+
+	if _, err := intervalBinOp(lhs, rhs, "^"); err == nil {
+		t.Fatalf("intervalBinOp(^) with a negative exponent = nil error, want one")
+	}
+}
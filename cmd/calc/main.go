@@ -0,0 +1,75 @@
+// Command calc is a thin REPL wrapper around the calc package: it owns
+// flag parsing and the read-eval-print loop, while all lexing, parsing,
+// and evaluation live in calc so they can be reused and unit-tested
+// independently of this CLI.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/Kayyo321/Shunting-Yard-Calculator/calc"
+)
+
+func main() {
+	bigMode := flag.Bool("big", false, "use arbitrary-precision math/big arithmetic (big.Int/big.Rat) instead of float64")
+	intervalMode := flag.Bool("interval", false, "use interval arithmetic ([lo, hi] enclosures) instead of float64")
+	bigPrec := flag.Uint("big-precision", 0, "big.Float working precision, in bits, for --big results with no exact closed form (0 selects the default)")
+	flag.Parse()
+
+	if *bigMode && *intervalMode {
+		fmt.Println("Error: --big and --interval cannot be used together")
+		os.Exit(1)
+	}
+
+	mode := calc.KindFloat
+	switch {
+	case *bigMode:
+		mode = calc.KindBigInt
+	case *intervalMode:
+		mode = calc.KindInterval
+	}
+
+	env := calc.NewEnv(mode, *bigPrec)
+	evaluator := calc.Evaluator{Mode: mode, Precision: *bigPrec}
+	scanner := bufio.NewScanner(os.Stdin)
+
+	// 'x'/'X' written directly against a preceding number with no
+	// operator (e.g. "3x4") is still read as multiply, matching "xNN".
+	// A variable named x/X can't be referenced that way - write "2*x".
+	fmt.Println(`Note: "3x4" still means "3 * 4", so a variable named x/X can't be referenced right after a number without an operator - write "2*x", not "2x".`)
+
+	for {
+		fmt.Print("Enter an mathematical expression ('exit' to stop): ")
+		scanner.Scan()
+		exprStr := scanner.Text()
+		fmt.Println()
+
+		if exprStr == "exit" {
+			break
+		}
+
+		var parser calc.Parser
+		n, err := parser.Parse(calc.NewLexer(exprStr, mode))
+		if err != nil {
+			fmt.Println("Error:", err)
+			fmt.Println()
+			continue
+		}
+
+		value, err := evaluator.Eval(n, env)
+		if err != nil {
+			fmt.Println("Error:", err)
+			fmt.Println()
+			continue
+		}
+
+		fmt.Println("That evaluates out to:\n", value.String())
+		if value.Warning != "" {
+			fmt.Println("Warning:", value.Warning)
+		}
+		fmt.Println()
+	}
+}